@@ -0,0 +1,146 @@
+package chess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Board represents the placement of pieces on the sixty-four squares of a
+// chess board.
+type Board struct {
+	squares [64]Piece
+	// promoted marks squares holding a piece that reached its current
+	// square via pawn promotion. It has no bearing on standard chess; it
+	// only matters under VariantCrazyhouse, where a captured piece that
+	// was promoted joins the capturing side's pocket as a pawn rather
+	// than as the piece it was promoted to.
+	promoted [64]bool
+}
+
+// NewBoard returns a board with the given squares.
+func NewBoard(squares [64]Piece) *Board {
+	b := &Board{}
+	b.squares = squares
+	return b
+}
+
+// Piece returns the piece occupying the given square, or NoPiece if the
+// square is empty or sq is NoSquare.
+func (b *Board) Piece(sq Square) Piece {
+	if sq < A1 || sq > H8 {
+		return NoPiece
+	}
+	return b.squares[sq]
+}
+
+func (b *Board) setPiece(sq Square, p Piece) {
+	b.squares[sq] = p
+}
+
+func (b *Board) isPromoted(sq Square) bool {
+	if sq < A1 || sq > H8 {
+		return false
+	}
+	return b.promoted[sq]
+}
+
+func (b *Board) setPromoted(sq Square, v bool) {
+	if sq < A1 || sq > H8 {
+		return
+	}
+	b.promoted[sq] = v
+}
+
+func (b *Board) copy() *Board {
+	cp := &Board{}
+	cp.squares = b.squares
+	cp.promoted = b.promoted
+	return cp
+}
+
+// String returns the FEN piece placement field for the board, e.g.
+// "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR".
+func (b *Board) String() string {
+	var rows []string
+	for r := Rank8; ; r-- {
+		row := ""
+		empty := 0
+		for f := FileA; f <= FileH; f++ {
+			p := b.Piece(NewSquare(f, r))
+			if p == NoPiece {
+				empty++
+				continue
+			}
+			if empty > 0 {
+				row += strconv.Itoa(empty)
+				empty = 0
+			}
+			row += p.String()
+		}
+		if empty > 0 {
+			row += strconv.Itoa(empty)
+		}
+		rows = append(rows, row)
+		if r == Rank1 {
+			break
+		}
+	}
+	return strings.Join(rows, "/")
+}
+
+// Draw renders the board as an ASCII diagram, useful for debugging and test
+// failure output.
+func (b *Board) Draw() string {
+	sb := &strings.Builder{}
+	sb.WriteString(" +-----------------+\n")
+	for r := Rank8; ; r-- {
+		sb.WriteString(fmt.Sprintf("%d| ", int(r)+1))
+		for f := FileA; f <= FileH; f++ {
+			p := b.Piece(NewSquare(f, r))
+			if p == NoPiece {
+				sb.WriteString(". ")
+				continue
+			}
+			sb.WriteString(p.String() + " ")
+		}
+		sb.WriteString("|\n")
+		if r == Rank1 {
+			break
+		}
+	}
+	sb.WriteString(" +-----------------+\n")
+	sb.WriteString("   a b c d e f g h\n")
+	return sb.String()
+}
+
+func fenBoardField(s string) (*Board, error) {
+	rows := strings.Split(s, "/")
+	if len(rows) != 8 {
+		return nil, fmt.Errorf("chess: invalid FEN board field %q", s)
+	}
+	b := &Board{}
+	for i, row := range rows {
+		r := Rank8 - Rank(i)
+		f := FileA
+		for _, c := range row {
+			if c >= '1' && c <= '8' {
+				f += File(c - '0')
+				continue
+			}
+			p, ok := fenCharToPieceMap[string(c)]
+			if !ok {
+				return nil, fmt.Errorf("chess: invalid FEN piece character %q", string(c))
+			}
+			if f > FileH {
+				return nil, fmt.Errorf("chess: invalid FEN board field %q", s)
+			}
+			b.setPiece(NewSquare(f, r), p)
+			f++
+		}
+		if f != FileH+1 {
+			return nil, fmt.Errorf("chess: invalid FEN board field %q", s)
+		}
+	}
+	return b, nil
+}
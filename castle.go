@@ -0,0 +1,87 @@
+package chess
+
+import "strings"
+
+// Side indicates one of the two sides of the board a king may castle
+// towards.
+type Side int8
+
+const (
+	// KingSide is the side of the board the king starts closer to (the
+	// e/f/g/h files in a standard game).
+	KingSide Side = iota
+	// QueenSide is the side of the board the queen starts closer to (the
+	// a/b/c/d files in a standard game).
+	QueenSide
+)
+
+func colorIndex(c Color) int {
+	if c == Black {
+		return 1
+	}
+	return 0
+}
+
+// CastleRights records, independently for each color and side, whether
+// castling is still available and which file the castling rook started on.
+// The rook file is tracked explicitly (rather than assumed to be the a- or
+// h-file) so that Chess960/Fischer Random starting positions, where the
+// rooks may begin on any file, are represented correctly.
+type CastleRights struct {
+	present  [2][2]bool
+	rookFile [2][2]File
+}
+
+// CanCastle reports whether the given color may still castle to the given
+// side.
+func (cr CastleRights) CanCastle(c Color, s Side) bool {
+	return cr.present[colorIndex(c)][s]
+}
+
+// RookFile returns the file of the rook that would participate in castling
+// for the given color and side. The second return value is false if that
+// castling right is not present.
+func (cr CastleRights) RookFile(c Color, s Side) (File, bool) {
+	ci := colorIndex(c)
+	if !cr.present[ci][s] {
+		return 0, false
+	}
+	return cr.rookFile[ci][s], true
+}
+
+// Add grants the given color and side the ability to castle, using the
+// rook on rookFile.
+func (cr *CastleRights) Add(c Color, s Side, rookFile File) {
+	ci := colorIndex(c)
+	cr.present[ci][s] = true
+	cr.rookFile[ci][s] = rookFile
+}
+
+// Remove revokes the given color and side's ability to castle.
+func (cr *CastleRights) Remove(c Color, s Side) {
+	ci := colorIndex(c)
+	cr.present[ci][s] = false
+}
+
+// String returns the standard FEN castling field, e.g. "KQkq", using the
+// letters K/Q/k/q rather than rook files. Use Position.ShredderFENString for
+// the Chess960-aware Shredder-FEN form.
+func (cr CastleRights) String() string {
+	sb := &strings.Builder{}
+	if cr.CanCastle(White, KingSide) {
+		sb.WriteString("K")
+	}
+	if cr.CanCastle(White, QueenSide) {
+		sb.WriteString("Q")
+	}
+	if cr.CanCastle(Black, KingSide) {
+		sb.WriteString("k")
+	}
+	if cr.CanCastle(Black, QueenSide) {
+		sb.WriteString("q")
+	}
+	if sb.Len() == 0 {
+		return "-"
+	}
+	return sb.String()
+}
@@ -0,0 +1,97 @@
+package chess
+
+import "testing"
+
+// chess960FEN is a bare-board setup with the white king on e1 and its rooks
+// on the non-a/h files c1 (queenside) and f1 (kingside), used to exercise
+// the rook-file-aware castling model without relying on a full starting
+// position.
+const chess960FEN = "4k3/8/8/8/8/8/8/2R1KR2 w KQ - 0 1"
+
+func TestChess960XFENShorthandResolvesOutermostRook(t *testing.T) {
+	pos := unsafeFEN(chess960FEN)
+	if f, ok := pos.castleRights.RookFile(White, KingSide); !ok || f != FileF {
+		t.Fatalf("expected White kingside rook file F, got %v (ok=%v)", f, ok)
+	}
+	if f, ok := pos.castleRights.RookFile(White, QueenSide); !ok || f != FileC {
+		t.Fatalf("expected White queenside rook file C, got %v (ok=%v)", f, ok)
+	}
+}
+
+func TestChess960CastlingLegalityWithShiftedRooks(t *testing.T) {
+	pos := unsafeFEN(chess960FEN)
+	var kingSide, queenSide *Move
+	for _, m := range pos.ValidMoves() {
+		if m.HasTag(KingSideCastle) {
+			kingSide = m
+		}
+		if m.HasTag(QueenSideCastle) {
+			queenSide = m
+		}
+	}
+	if kingSide == nil {
+		t.Fatal("expected a legal kingside castle with the rook on f1")
+	}
+	if kingSide.s1 != E1 || kingSide.s2 != G1 {
+		t.Fatalf("expected kingside castle e1g1, got %s%s", kingSide.s1, kingSide.s2)
+	}
+	if queenSide == nil {
+		t.Fatal("expected a legal queenside castle with the rook on c1")
+	}
+	if queenSide.s1 != E1 || queenSide.s2 != C1 {
+		t.Fatalf("expected queenside castle e1c1, got %s%s", queenSide.s1, queenSide.s2)
+	}
+
+	if got, want := (AlgebraicNotation{}).Encode(pos, kingSide), "O-O"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+	if got, want := (AlgebraicNotation{}).Encode(pos, queenSide), "O-O-O"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestChess960UCIKingCapturesRookRoundTrip(t *testing.T) {
+	pos := unsafeFEN(chess960FEN)
+	var kingSide *Move
+	for _, m := range pos.ValidMoves() {
+		if m.HasTag(KingSideCastle) {
+			kingSide = m
+		}
+	}
+	if kingSide == nil {
+		t.Fatal("expected a legal kingside castle")
+	}
+
+	notation := UCINotation{Chess960: true}
+	encoded := notation.Encode(pos, kingSide)
+	if encoded != "e1f1" {
+		t.Fatalf("expected king-captures-rook notation %q, got %q", "e1f1", encoded)
+	}
+	if got := (UCINotation{}).Encode(pos, kingSide); got != "e1g1" {
+		t.Fatalf("expected standard UCI notation %q, got %q", "e1g1", got)
+	}
+
+	decoded, err := notation.Decode(pos, encoded)
+	if err != nil {
+		t.Fatalf("unexpected error decoding %q: %v", encoded, err)
+	}
+	if decoded.s1 != kingSide.s1 || decoded.s2 != kingSide.s2 || !decoded.HasTag(KingSideCastle) {
+		t.Fatalf("expected decode of %q to round-trip to the kingside castle, got %s", encoded, decoded)
+	}
+}
+
+func TestChess960ShredderFENRoundTrip(t *testing.T) {
+	pos := unsafeFEN(chess960FEN)
+	shredder := pos.ShredderFENString()
+	if want := "4k3/8/8/8/8/8/8/2R1KR2 w FC - 0 1"; shredder != want {
+		t.Fatalf("expected %q, got %q", want, shredder)
+	}
+
+	roundTripped := unsafeFEN(shredder)
+	if f, ok := roundTripped.castleRights.RookFile(White, KingSide); !ok || f != FileF {
+		t.Fatalf("expected kingside rook file F after round-trip, got %v (ok=%v)", f, ok)
+	}
+	if f, ok := roundTripped.castleRights.RookFile(White, QueenSide); !ok || f != FileC {
+		t.Fatalf("expected queenside rook file C after round-trip, got %v (ok=%v)", f, ok)
+	}
+}
@@ -0,0 +1,47 @@
+package chess
+
+// Color represents the color of a chess piece or the player to move.
+type Color int8
+
+const (
+	NoColor Color = iota
+	White
+	Black
+)
+
+// Other returns the opposite color.
+func (c Color) Other() Color {
+	switch c {
+	case White:
+		return Black
+	case Black:
+		return White
+	default:
+		return NoColor
+	}
+}
+
+// String implements the Stringer interface, returning the FEN side-to-move
+// character for White and Black.
+func (c Color) String() string {
+	switch c {
+	case White:
+		return "w"
+	case Black:
+		return "b"
+	default:
+		return "-"
+	}
+}
+
+// Name returns the English name of the color.
+func (c Color) Name() string {
+	switch c {
+	case White:
+		return "White"
+	case Black:
+		return "Black"
+	default:
+		return "No Color"
+	}
+}
@@ -0,0 +1,283 @@
+package chess
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// decodeFEN parses s as a Forsyth-Edwards Notation string and returns the
+// Position it describes. Six fields must be present and separated by a
+// single space, plus an optional seventh Three-Check remaining-checks
+// field ("3+3"). The castling field is parsed with the X-FEN relaxations
+// described by shakmaty: letters may appear in any order, duplicate
+// letters are ignored, and the classic "K"/"Q" shorthand is resolved to
+// the outermost rook on that side of the king when no rook occupies the
+// file the letter would otherwise name. A Crazyhouse "[QRBNPqrbnp]"
+// pocket suffix on the board field is also accepted.
+func decodeFEN(s string) (*Position, error) {
+	fields := strings.Split(s, " ")
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, fmt.Errorf("chess: invalid FEN %q: expected 6 or 7 fields, got %d", s, len(fields))
+	}
+
+	boardField, pocketField, hasPockets := splitBoardAndPocket(fields[0])
+	variant := VariantStandard
+	var pockets [2]map[PieceType]int
+	if hasPockets {
+		variant = VariantCrazyhouse
+		p, err := parsePockets(pocketField)
+		if err != nil {
+			return nil, err
+		}
+		pockets = p
+	}
+
+	board, err := fenBoardField(boardField)
+	if err != nil {
+		return nil, err
+	}
+
+	var turn Color
+	switch fields[1] {
+	case "w":
+		turn = White
+	case "b":
+		turn = Black
+	default:
+		return nil, fmt.Errorf("chess: invalid FEN side to move %q", fields[1])
+	}
+
+	castleRights, err := parseCastleRights(fields[2], board)
+	if err != nil {
+		return nil, err
+	}
+
+	epSq := NoSquare
+	if fields[3] != "-" {
+		sq, ok := squareFromString(fields[3])
+		if !ok {
+			return nil, fmt.Errorf("chess: invalid FEN en passant square %q", fields[3])
+		}
+		epSq = sq
+	}
+
+	halfMove, err := strconv.Atoi(fields[4])
+	if err != nil || halfMove < 0 {
+		return nil, fmt.Errorf("chess: invalid FEN halfmove clock %q", fields[4])
+	}
+
+	moveCount, err := strconv.Atoi(fields[5])
+	if err != nil || moveCount < 1 {
+		return nil, fmt.Errorf("chess: invalid FEN fullmove number %q", fields[5])
+	}
+
+	var checksRemaining [2]int
+	if len(fields) == 7 {
+		w, b, err := parseChecksField(fields[6])
+		if err != nil {
+			return nil, err
+		}
+		variant = VariantThreeCheck
+		checksRemaining = [2]int{w, b}
+	}
+
+	return &Position{
+		board:           board,
+		turn:            turn,
+		castleRights:    castleRights,
+		enPassantSquare: epSq,
+		halfMoveClock:   halfMove,
+		moveCount:       moveCount,
+		variant:         variant,
+		pockets:         pockets,
+		checksRemaining: checksRemaining,
+	}, nil
+}
+
+// splitBoardAndPocket splits a Crazyhouse board field from its pocket
+// suffix, accepting both the bracketed "...RNBQKBNR[QRBNPqrbnp]" form and
+// the slash-separated "...RNBQKBNR/qrbnp" form some tools emit. The second
+// return value is the pocket contents (without brackets); the third
+// reports whether a pocket suffix was present at all.
+func splitBoardAndPocket(field string) (string, string, bool) {
+	if idx := strings.IndexByte(field, '['); idx >= 0 && strings.HasSuffix(field, "]") {
+		return field[:idx], field[idx+1 : len(field)-1], true
+	}
+	if parts := strings.Split(field, "/"); len(parts) == 9 {
+		return strings.Join(parts[:8], "/"), parts[8], true
+	}
+	return field, "", false
+}
+
+// parsePockets decodes a Crazyhouse pocket string such as "QRrbnp" into
+// per-color piece counts, uppercase letters counting toward White's pocket
+// and lowercase toward Black's.
+func parsePockets(s string) ([2]map[PieceType]int, error) {
+	var pockets [2]map[PieceType]int
+	pockets[0] = map[PieceType]int{}
+	pockets[1] = map[PieceType]int{}
+	for _, ch := range s {
+		var c Color
+		switch {
+		case ch >= 'A' && ch <= 'Z':
+			c = White
+		case ch >= 'a' && ch <= 'z':
+			c = Black
+		default:
+			return pockets, fmt.Errorf("chess: invalid pocket character %q", string(ch))
+		}
+		upper := strings.ToUpper(string(ch))
+		pt, ok := charToPieceTypeMap[upper]
+		if !ok || pt == King {
+			return pockets, fmt.Errorf("chess: invalid pocket piece character %q", string(ch))
+		}
+		pockets[colorIndex(c)][pt]++
+	}
+	return pockets, nil
+}
+
+// pocketsToFENString encodes pockets as a Crazyhouse "[...]" suffix body,
+// in ascending piece-value order (pawn, knight, bishop, rook, queen),
+// White's pieces uppercase followed by Black's lowercase.
+func pocketsToFENString(pockets [2]map[PieceType]int) string {
+	order := []PieceType{Pawn, Knight, Bishop, Rook, Queen}
+	sb := &strings.Builder{}
+	for _, c := range []Color{White, Black} {
+		pocket := pockets[colorIndex(c)]
+		for _, pt := range order {
+			letter := pt.String()
+			if c == Black {
+				letter = strings.ToLower(letter)
+			}
+			for i := 0; i < pocket[pt]; i++ {
+				sb.WriteString(letter)
+			}
+		}
+	}
+	return sb.String()
+}
+
+var checksFieldPattern = regexp.MustCompile(`^\+?(\d+)\+(\d+)$`)
+
+// parseChecksField decodes a Three-Check remaining-checks field such as
+// "3+3" or "+0+0" into the number of checks White and Black must still
+// deliver to win.
+func parseChecksField(s string) (int, int, error) {
+	m := checksFieldPattern.FindStringSubmatch(s)
+	if m == nil {
+		return 0, 0, fmt.Errorf("chess: invalid FEN remaining-checks field %q", s)
+	}
+	w, err := strconv.Atoi(m[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("chess: invalid FEN remaining-checks field %q", s)
+	}
+	b, err := strconv.Atoi(m[2])
+	if err != nil {
+		return 0, 0, fmt.Errorf("chess: invalid FEN remaining-checks field %q", s)
+	}
+	return w, b, nil
+}
+
+// parseCastleRights decodes a FEN castling field against board, accepting
+// standard "KQkq", Shredder-FEN file letters ("HAha"), and mixtures of the
+// two as produced by different X-FEN-aware tools.
+func parseCastleRights(s string, b *Board) (CastleRights, error) {
+	var cr CastleRights
+	if s == "-" {
+		return cr, nil
+	}
+	seen := map[rune]bool{}
+	for _, ch := range s {
+		if seen[ch] {
+			continue
+		}
+		seen[ch] = true
+
+		var c Color
+		switch {
+		case ch >= 'A' && ch <= 'Z':
+			c = White
+		case ch >= 'a' && ch <= 'z':
+			c = Black
+		default:
+			return cr, fmt.Errorf("chess: invalid castling rights character %q", string(ch))
+		}
+
+		kingSq := findKing(b, c)
+		if kingSq == NoSquare {
+			return cr, fmt.Errorf("chess: no %s king to assign castling rights %q to", c.Name(), string(ch))
+		}
+		kingFile := kingSq.File()
+
+		lower := ch
+		if lower >= 'A' && lower <= 'Z' {
+			lower += 'a' - 'A'
+		}
+
+		if lower == 'k' || lower == 'q' {
+			side := KingSide
+			if lower == 'q' {
+				side = QueenSide
+			}
+			rookFile, ok := findOutermostRook(b, c, kingFile, side)
+			if !ok {
+				return cr, fmt.Errorf("chess: no rook found for castling right %q", string(ch))
+			}
+			cr.Add(c, side, rookFile)
+			continue
+		}
+
+		f, ok := charToFileMap[string(lower)]
+		if !ok {
+			return cr, fmt.Errorf("chess: invalid castling rights character %q", string(ch))
+		}
+		side := QueenSide
+		if f > kingFile {
+			side = KingSide
+		}
+		cr.Add(c, side, f)
+	}
+	return cr, nil
+}
+
+// findOutermostRook finds the rook for color c on the given side of
+// kingFile that sits closest to the edge of the board, which is what the
+// classic "K"/"Q" castling letters refer to once a position may have more
+// than one rook per side of the king (Chess960).
+func findOutermostRook(b *Board, c Color, kingFile File, side Side) (File, bool) {
+	rookPiece := NewPiece(Rook, c)
+	rank := backRankFor(c)
+	if side == KingSide {
+		for f := FileH; f > kingFile; f-- {
+			if b.Piece(NewSquare(f, rank)) == rookPiece {
+				return f, true
+			}
+		}
+		return 0, false
+	}
+	for f := FileA; f < kingFile; f++ {
+		if b.Piece(NewSquare(f, rank)) == rookPiece {
+			return f, true
+		}
+	}
+	return 0, false
+}
+
+// unsafeFEN decodes s and panics if it is invalid. It is used throughout
+// the test suite to build positions from known-good FEN strings and keeps
+// its strict, single-space-separated semantics regardless of the leniency
+// offered by FENDecoder.
+func unsafeFEN(s string) *Position {
+	pos, err := (FENDecoder{}).Decode(s)
+	if err != nil {
+		panic(err)
+	}
+	return pos
+}
+
+// FEN decodes s as strict Forsyth-Edwards Notation.
+func FEN(s string) (*Position, error) {
+	return (FENDecoder{}).Decode(s)
+}
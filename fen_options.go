@@ -0,0 +1,126 @@
+package chess
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// defaultFENFields holds the field values of the empty-board FEN
+// "8/8/8/8/8/8/8/8 w - - 0 1", used by FENOptions.Relaxed to fill in
+// trailing fields a caller's FEN string omits.
+var defaultFENFields = strings.Fields("8/8/8/8/8/8/8/8 w - - 0 1")
+
+// FENOptions configures the leniency of a FENDecoder.
+type FENOptions struct {
+	// Relaxed, when true, accepts FEN strings that don't strictly
+	// conform to the spec: trailing fields (side to move, castling
+	// rights, en passant square, halfmove clock, fullmove number) may be
+	// omitted and are filled in with the defaults from the empty-board
+	// FEN "8/8/8/8/8/8/8/8 w - - 0 1"; runs of more than one space
+	// between fields are tolerated; the side to move is accepted in
+	// either case; and an empty or malformed en passant square is
+	// treated as absent rather than rejected. This mirrors the leniency
+	// shakmaty's FEN parser extends to FENs pulled from engine logs and
+	// databases that don't strictly conform. When false (the default),
+	// decoding requires exactly six single-space-separated fields, plus
+	// an optional seventh Three-Check remaining-checks field.
+	Relaxed bool
+}
+
+// FENDecoder decodes Forsyth-Edwards Notation strings into Positions,
+// according to its Options.
+type FENDecoder struct {
+	Options FENOptions
+}
+
+// Decode parses s as FEN, honoring d.Options.
+func (d FENDecoder) Decode(s string) (*Position, error) {
+	if !d.Options.Relaxed {
+		return decodeFEN(s)
+	}
+	return decodeRelaxedFEN(s)
+}
+
+// decodeRelaxedFEN implements FENOptions.Relaxed decoding.
+func decodeRelaxedFEN(s string) (*Position, error) {
+	fields := strings.Fields(s)
+	if len(fields) == 0 {
+		return nil, fmt.Errorf("chess: invalid FEN %q: no board field", s)
+	}
+	checksField := ""
+	if len(fields) > len(defaultFENFields) {
+		checksField = fields[len(defaultFENFields)]
+		fields = fields[:len(defaultFENFields)]
+	}
+	for len(fields) < len(defaultFENFields) {
+		fields = append(fields, defaultFENFields[len(fields)])
+	}
+
+	boardField, pocketField, hasPockets := splitBoardAndPocket(fields[0])
+	variant := VariantStandard
+	var pockets [2]map[PieceType]int
+	if hasPockets {
+		variant = VariantCrazyhouse
+		p, err := parsePockets(pocketField)
+		if err != nil {
+			return nil, err
+		}
+		pockets = p
+	}
+
+	board, err := fenBoardField(boardField)
+	if err != nil {
+		return nil, err
+	}
+
+	var turn Color
+	switch strings.ToLower(fields[1]) {
+	case "w":
+		turn = White
+	case "b":
+		turn = Black
+	default:
+		return nil, fmt.Errorf("chess: invalid FEN side to move %q", fields[1])
+	}
+
+	castleRights, err := parseCastleRights(fields[2], board)
+	if err != nil {
+		return nil, err
+	}
+
+	epSq := NoSquare
+	if sq, ok := squareFromString(fields[3]); ok {
+		epSq = sq
+	}
+
+	halfMove, err := strconv.Atoi(fields[4])
+	if err != nil || halfMove < 0 {
+		halfMove = 0
+	}
+
+	moveCount, err := strconv.Atoi(fields[5])
+	if err != nil || moveCount < 1 {
+		moveCount = 1
+	}
+
+	var checksRemaining [2]int
+	if checksField != "" {
+		if w, b, err := parseChecksField(checksField); err == nil {
+			variant = VariantThreeCheck
+			checksRemaining = [2]int{w, b}
+		}
+	}
+
+	return &Position{
+		board:           board,
+		turn:            turn,
+		castleRights:    castleRights,
+		enPassantSquare: epSq,
+		halfMoveClock:   halfMove,
+		moveCount:       moveCount,
+		variant:         variant,
+		pockets:         pockets,
+		checksRemaining: checksRemaining,
+	}, nil
+}
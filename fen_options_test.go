@@ -0,0 +1,43 @@
+package chess
+
+import "testing"
+
+func TestFENDecoderRelaxedMissingFields(t *testing.T) {
+	pos, err := (FENDecoder{Options: FENOptions{Relaxed: true}}).Decode("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w - - 0 1")
+	if pos.String() != want.String() {
+		t.Fatalf("got %s, want %s", pos, want)
+	}
+}
+
+func TestFENDecoderRelaxedExtraSpacesAndCase(t *testing.T) {
+	pos, err := (FENDecoder{Options: FENOptions{Relaxed: true}}).Decode("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR  B  KQkq  -  0  1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pos.Turn() != Black {
+		t.Fatalf("expected Black to move, got %s", pos.Turn())
+	}
+}
+
+func TestFENDecoderRelaxedMalformedEnPassant(t *testing.T) {
+	pos, err := (FENDecoder{Options: FENOptions{Relaxed: true}}).Decode("rnbqkbnr/pppppppp/8/8/4P3/8/PPPP1PPP/RNBQKBNR b KQkq xx 0 1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pos.EnPassantSquare() != NoSquare {
+		t.Fatalf("expected malformed en passant square to be cleared, got %s", pos.EnPassantSquare())
+	}
+}
+
+func TestFENDecoderStrictRejectsWhatRelaxedAccepts(t *testing.T) {
+	if _, err := (FENDecoder{}).Decode("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR"); err == nil {
+		t.Fatal("expected strict decoding to reject a FEN missing trailing fields")
+	}
+	if _, err := FEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR  b KQkq - 0 1"); err == nil {
+		t.Fatal("expected strict decoding to reject extra spaces between fields")
+	}
+}
@@ -0,0 +1,103 @@
+package chess
+
+import (
+	"fmt"
+	"strings"
+)
+
+var pieceToWhiteGlyph = map[PieceType]string{
+	King:   "♔",
+	Queen:  "♕",
+	Rook:   "♖",
+	Bishop: "♗",
+	Knight: "♘",
+}
+
+var pieceToBlackGlyph = map[PieceType]string{
+	King:   "♚",
+	Queen:  "♛",
+	Rook:   "♜",
+	Bishop: "♝",
+	Knight: "♞",
+}
+
+var figurineGlyphToASCII = map[rune]string{
+	'♔': "K", '♕': "Q", '♖': "R", '♗': "B", '♘': "N",
+	'♚': "K", '♛': "Q", '♜': "R", '♝': "B", '♞': "N",
+}
+
+// FigurineNotation encodes and decodes moves in Figurine Algebraic
+// Notation (FAN): standard algebraic notation with the ASCII piece
+// letters K/Q/R/B/N replaced by the corresponding Unicode chess glyph,
+// e.g. "♘xf7+" or "b8=♕+". File/rank, disambiguation, captures, check,
+// checkmate, promotion, and castling all follow the same rules as
+// AlgebraicNotation.
+type FigurineNotation struct {
+	// AllowAlgebraic, when true, also accepts plain ASCII algebraic
+	// notation as produced by AlgebraicNotation (e.g. "Nf3"), letting
+	// FigurineNotation be used as a drop-in superset decoder for input
+	// that mixes FAN and SAN.
+	AllowAlgebraic bool
+}
+
+// Encode implements the Encoder interface.
+func (FigurineNotation) Encode(pos *Position, m *Move) string {
+	if m.HasTag(KingSideCastle) {
+		return "O-O" + checkSuffix(m)
+	}
+	if m.HasTag(QueenSideCastle) {
+		return "O-O-O" + checkSuffix(m)
+	}
+
+	piece := pos.board.Piece(m.s1)
+	glyphFor := func(pt PieceType) string {
+		if piece.Color() == Black {
+			return pieceToBlackGlyph[pt]
+		}
+		return pieceToWhiteGlyph[pt]
+	}
+
+	sb := &strings.Builder{}
+	if piece.Type() != Pawn {
+		sb.WriteString(glyphFor(piece.Type()))
+		sb.WriteString(disambiguation(pos, m, piece))
+	} else if isCapture(m) {
+		sb.WriteString(m.s1.File().String())
+	}
+	if isCapture(m) {
+		sb.WriteString("x")
+	}
+	sb.WriteString(m.s2.String())
+	if m.promo != NoPieceType {
+		sb.WriteString("=" + glyphFor(m.promo))
+	}
+	sb.WriteString(checkSuffix(m))
+	return sb.String()
+}
+
+// Decode implements the Decoder interface. It accepts the figurine glyph
+// for either color, since many game databases store FAN using only the
+// white-piece glyphs regardless of which side is moving.
+func (f FigurineNotation) Decode(pos *Position, s string) (*Move, error) {
+	if pos == nil {
+		return nil, fmt.Errorf("chess: cannot decode figurine notation %q without a position", s)
+	}
+
+	runes := []rune(s)
+	for _, r := range runes {
+		if !f.AllowAlgebraic && r >= 'A' && r <= 'Z' && r != 'O' {
+			return nil, fmt.Errorf("chess: figurine notation %q: expected a piece glyph, not an ASCII letter (set FigurineNotation.AllowAlgebraic to accept plain algebraic notation)", s)
+		}
+	}
+
+	sb := &strings.Builder{}
+	for _, r := range runes {
+		if ascii, ok := figurineGlyphToASCII[r]; ok {
+			sb.WriteString(ascii)
+		} else {
+			sb.WriteRune(r)
+		}
+	}
+
+	return AlgebraicNotation{}.Decode(pos, sb.String())
+}
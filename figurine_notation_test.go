@@ -0,0 +1,110 @@
+package chess
+
+import "testing"
+
+func TestFigurinePromotionWithCheck(t *testing.T) {
+	promoPos := unsafeFEN("8/1P2k3/8/8/8/8/8/8 w - - 0 1")
+	promoMove := &Move{s1: B7, s2: B8, promo: Queen, tags: Check}
+
+	notation := FigurineNotation{}
+	result := notation.Encode(promoPos, promoMove)
+	if result != "b8=♕+" {
+		t.Fatalf("expected 'b8=♕+', got '%s'", result)
+	}
+}
+
+func TestFigurineDecodePromotionWithCheck(t *testing.T) {
+	promoPos := unsafeFEN("8/1P2k3/8/8/8/8/8/8 w - - 0 1")
+	notation := FigurineNotation{}
+
+	move, err := notation.Decode(promoPos, "b8=♕+")
+	if err != nil {
+		t.Fatalf("unexpected error decoding %q: %v", "b8=♕+", err)
+	}
+	if move.s1 != B7 || move.s2 != B8 || move.promo != Queen {
+		t.Fatalf("expected b7b8=Q, got %s", move)
+	}
+}
+
+func TestFigurineEncodeKnightCapture(t *testing.T) {
+	complexPos := unsafeFEN("r1n1k2r/pP1pqpb1/b3pnp1/2pPN3/1p2P3/2N2Q1p/PP1BBPPP/R3K2R w KQkq c6 0 2")
+	want, err := (AlgebraicNotation{}).Decode(complexPos, "Nxf7")
+	if err != nil {
+		t.Fatalf("unexpected error decoding reference move: %v", err)
+	}
+	result := (FigurineNotation{}).Encode(complexPos, want)
+	if result != "♘xf7" {
+		t.Fatalf("expected '♘xf7', got '%s'", result)
+	}
+}
+
+func TestFigurineDecodeAcceptsEitherColorGlyph(t *testing.T) {
+	complexPos := unsafeFEN("r1n1k2r/pP1pqpb1/b3pnp1/2pPN3/1p2P3/2N2Q1p/PP1BBPPP/R3K2R w KQkq c6 0 2")
+	notation := FigurineNotation{}
+
+	white, err := notation.Decode(complexPos, "♘xf7+")
+	if err != nil {
+		t.Fatalf("unexpected error decoding white glyph: %v", err)
+	}
+	black, err := notation.Decode(complexPos, "♞xf7+")
+	if err != nil {
+		t.Fatalf("unexpected error decoding black glyph for a white move: %v", err)
+	}
+	if white.String() != black.String() {
+		t.Fatalf("expected both glyphs to resolve to the same move, got %s and %s", white, black)
+	}
+}
+
+func TestFigurineDecodeRejectsPlainAlgebraicByDefault(t *testing.T) {
+	complexPos := unsafeFEN("r1n1k2r/pP1pqpb1/b3pnp1/2pPN3/1p2P3/2N2Q1p/PP1BBPPP/R3K2R w KQkq c6 0 2")
+	notation := FigurineNotation{}
+	if _, err := notation.Decode(complexPos, "Nxf7+"); err == nil {
+		t.Fatal("expected plain algebraic notation to be rejected by default")
+	}
+}
+
+func TestFigurineDecodeAllowAlgebraicSuperset(t *testing.T) {
+	complexPos := unsafeFEN("r1n1k2r/pP1pqpb1/b3pnp1/2pPN3/1p2P3/2N2Q1p/PP1BBPPP/R3K2R w KQkq c6 0 2")
+	notation := FigurineNotation{AllowAlgebraic: true}
+	if _, err := notation.Decode(complexPos, "Nxf7+"); err != nil {
+		t.Fatalf("expected plain algebraic notation to be accepted, got error: %v", err)
+	}
+}
+
+func BenchmarkFigurineEncode(b *testing.B) {
+	notation := FigurineNotation{}
+	positions := []*Position{startPos, midPos, complexPos}
+	moves := [][]*Move{startMoves, midMoves, complexMoves}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pos := positions[i%len(positions)]
+		move := moves[i%len(moves)][i%len(moves[i%len(moves)])]
+		notation.Encode(pos, move)
+	}
+}
+
+func BenchmarkFigurineDecode(b *testing.B) {
+	notation := FigurineNotation{}
+	samples := []struct {
+		pos  *Position
+		text string
+	}{
+		{startPos, "e4"},
+		{midPos, "O-O"},
+		{complexPos, "♘xf7+"},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sample := samples[i%len(samples)]
+		_, err := notation.Decode(sample.pos, sample.text)
+		if err != nil {
+			b.Fatalf("error decoding %s: %s", sample.text, err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package chess
+
+// startingPosition is the standard chess starting position.
+func startingPosition() *Position {
+	return unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+}
+
+// Game represents a single chess game: the sequence of moves played and
+// the positions they produced.
+type Game struct {
+	moves     []*Move
+	positions []*Position
+}
+
+// NewGame returns a new Game at the standard starting position, applying
+// any options such as PGN.
+func NewGame(options ...func(*Game)) *Game {
+	g := &Game{positions: []*Position{startingPosition()}}
+	for _, opt := range options {
+		opt(g)
+	}
+	return g
+}
+
+// Moves returns the moves played so far, in order.
+func (g *Game) Moves() []*Move {
+	return g.moves
+}
+
+// Position returns the current position.
+func (g *Game) Position() *Position {
+	return g.positions[len(g.positions)-1]
+}
+
+// Positions returns every position reached so far, starting with the
+// initial position.
+func (g *Game) Positions() []*Position {
+	return g.positions
+}
@@ -0,0 +1,86 @@
+package chess
+
+import "fmt"
+
+var iccfPromoDigit = map[PieceType]string{
+	Queen:  "1",
+	Rook:   "2",
+	Bishop: "3",
+	Knight: "4",
+}
+
+var iccfDigitPromo = map[byte]PieceType{
+	'1': Queen,
+	'2': Rook,
+	'3': Bishop,
+	'4': Knight,
+}
+
+// ICCFNotation encodes and decodes moves in the International Correspondence
+// Chess Federation's numeric notation, where each square is written as two
+// digits (file 1-8, rank 1-8), e.g. "5254" for e2e4. A promotion appends a
+// fifth digit: 1=Q, 2=R, 3=B, 4=N.
+type ICCFNotation struct{}
+
+func iccfSquare(sq Square) string {
+	return fmt.Sprintf("%d%d", int(sq.File())+1, int(sq.Rank())+1)
+}
+
+func squareFromICCF(s string) (Square, error) {
+	if len(s) != 2 || s[0] < '1' || s[0] > '8' || s[1] < '1' || s[1] > '8' {
+		return 0, fmt.Errorf("invalid ICCF square %q", s)
+	}
+	f := File(s[0] - '1')
+	r := Rank(s[1] - '1')
+	return NewSquare(f, r), nil
+}
+
+// Encode implements the Encoder interface.
+func (ICCFNotation) Encode(pos *Position, m *Move) string {
+	s := iccfSquare(m.s1) + iccfSquare(m.s2)
+	if m.promo != NoPieceType {
+		s += iccfPromoDigit[m.promo]
+	}
+	return s
+}
+
+// Decode implements the Decoder interface.
+func (ICCFNotation) Decode(pos *Position, s string) (*Move, error) {
+	if len(s) != 4 && len(s) != 5 {
+		return nil, fmt.Errorf("chess: invalid ICCF notation %q", s)
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return nil, fmt.Errorf("chess: invalid ICCF notation %q: non-digit character", s)
+		}
+	}
+
+	s1, err := squareFromICCF(s[0:2])
+	if err != nil {
+		return nil, fmt.Errorf("chess: invalid ICCF notation %q: bad origin square", s)
+	}
+	s2, err := squareFromICCF(s[2:4])
+	if err != nil {
+		return nil, fmt.Errorf("chess: invalid ICCF notation %q: bad destination square", s)
+	}
+
+	promo := NoPieceType
+	if len(s) == 5 {
+		pt, ok := iccfDigitPromo[s[4]]
+		if !ok {
+			return nil, fmt.Errorf("chess: invalid ICCF notation %q: bad promotion digit", s)
+		}
+		promo = pt
+	}
+
+	if pos == nil {
+		return nil, fmt.Errorf("chess: cannot decode ICCF notation %q without a position", s)
+	}
+
+	for _, m := range pos.ValidMoves() {
+		if m.s1 == s1 && m.s2 == s2 && m.promo == promo {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("chess: move %q is not valid in the current position", s)
+}
@@ -0,0 +1,93 @@
+package chess
+
+import "testing"
+
+func TestICCFEncode(t *testing.T) {
+	notation := ICCFNotation{}
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	move := &Move{s1: E2, s2: E4}
+	if got := notation.Encode(pos, move); got != "5254" {
+		t.Fatalf("expected 5254, got %s", got)
+	}
+}
+
+func TestICCFEncodeWithPromotion(t *testing.T) {
+	notation := ICCFNotation{}
+	pos := unsafeFEN("8/P7/8/8/8/8/8/8 w - - 0 1")
+	move := &Move{s1: A7, s2: A8, promo: Queen}
+	if got := notation.Encode(pos, move); got != "17181" {
+		t.Fatalf("expected 17181, got %s", got)
+	}
+}
+
+func TestICCFDecode(t *testing.T) {
+	notation := ICCFNotation{}
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	m, err := notation.Decode(pos, "5254")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.s1 != E2 || m.s2 != E4 {
+		t.Fatalf("expected e2e4, got %s%s", m.s1, m.s2)
+	}
+}
+
+func TestICCFDecodeWithPromotion(t *testing.T) {
+	notation := ICCFNotation{}
+	pos := unsafeFEN("8/P7/8/8/8/8/8/8 w - - 0 1")
+	m, err := notation.Decode(pos, "17181")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if m.promo != Queen {
+		t.Fatalf("expected promotion to Queen, got %s", m.promo)
+	}
+}
+
+func TestICCFDecodeInvalid(t *testing.T) {
+	notation := ICCFNotation{}
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	for _, s := range []string{"525", "e2e4", "5299", "52541"} {
+		if _, err := notation.Decode(pos, s); err == nil {
+			t.Fatalf("expected %q to be an invalid ICCF move", s)
+		}
+	}
+}
+
+func BenchmarkICCFEncode(b *testing.B) {
+	notation := ICCFNotation{}
+	positions := []*Position{startPos, midPos, complexPos}
+	moves := [][]*Move{startMoves, midMoves, complexMoves}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		pos := positions[i%len(positions)]
+		move := moves[i%len(moves)][i%len(moves[i%len(moves)])]
+		notation.Encode(pos, move)
+	}
+}
+
+func BenchmarkICCFDecode(b *testing.B) {
+	notation := ICCFNotation{}
+	samples := []struct {
+		pos  *Position
+		text string
+	}{
+		{startPos, "5254"},
+		{midPos, "5171"},
+		{complexPos, "5567"},
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for i := 0; i < b.N; i++ {
+		sample := samples[i%len(samples)]
+		_, err := notation.Decode(sample.pos, sample.text)
+		if err != nil {
+			b.Fatalf("error decoding %s: %s", sample.text, err)
+		}
+	}
+}
@@ -0,0 +1,85 @@
+package chess
+
+// MoveTag represents a notable consequence of a move, e.g. whether it was a
+// capture or gave check. A move may carry more than one tag.
+type MoveTag uint16
+
+const (
+	// KingSideCastle indicates that the move is a king side castle.
+	KingSideCastle MoveTag = 1 << iota
+	// QueenSideCastle indicates that the move is a queen side castle.
+	QueenSideCastle
+	// Capture indicates that the move captures a piece.
+	Capture
+	// EnPassant indicates that the move captures a pawn en passant.
+	EnPassant
+	// Check indicates that the move puts the opposing king in check.
+	Check
+	// Checkmate indicates that the move puts the opposing king in checkmate.
+	Checkmate
+	// Drop indicates that the move places a piece from the mover's pocket
+	// onto an empty square rather than moving a piece already on the
+	// board. Drop moves only occur under VariantCrazyhouse; S1 is
+	// NoSquare and Piece holds the dropped piece.
+	Drop
+)
+
+// Move represents a single move in a chess game, including the squares it
+// travels between, any promotion, and the tags describing its effect on the
+// resulting position.
+type Move struct {
+	s1       Square
+	s2       Square
+	promo    PieceType
+	piece    Piece
+	tags     MoveTag
+	position *Position
+}
+
+// S1 returns the origin square of the move.
+func (m *Move) S1() Square {
+	return m.s1
+}
+
+// S2 returns the destination square of the move.
+func (m *Move) S2() Square {
+	return m.s2
+}
+
+// Promo returns the promotion piece type of the move, or NoPieceType if the
+// move is not a promotion.
+func (m *Move) Promo() PieceType {
+	return m.promo
+}
+
+// Piece returns the piece that was moved, if known.
+func (m *Move) Piece() Piece {
+	return m.piece
+}
+
+// Tags returns the full set of tags describing the move.
+func (m *Move) Tags() MoveTag {
+	return m.tags
+}
+
+// HasTag returns whether the move carries the given tag.
+func (m *Move) HasTag(tag MoveTag) bool {
+	return m.tags&tag > 0
+}
+
+// AddTag adds the given tag to the move.
+func (m *Move) AddTag(tag MoveTag) {
+	m.tags |= tag
+}
+
+// Position returns the position resulting from the move, if the move was
+// produced by decoding against, or applying to, a Position.
+func (m *Move) Position() *Position {
+	return m.position
+}
+
+// String returns the UCI-style origin/destination encoding of the move,
+// e.g. "e2e4". It does not include tags or promotion.
+func (m *Move) String() string {
+	return m.s1.String() + m.s2.String()
+}
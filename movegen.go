@@ -0,0 +1,376 @@
+package chess
+
+type offset struct{ df, dr int8 }
+
+var knightOffsets = []offset{
+	{1, 2}, {2, 1}, {2, -1}, {1, -2},
+	{-1, -2}, {-2, -1}, {-2, 1}, {-1, 2},
+}
+
+var kingOffsets = []offset{
+	{1, 0}, {1, 1}, {0, 1}, {-1, 1},
+	{-1, 0}, {-1, -1}, {0, -1}, {1, -1},
+}
+
+var bishopDirs = []offset{{1, 1}, {1, -1}, {-1, 1}, {-1, -1}}
+var rookDirs = []offset{{1, 0}, {-1, 0}, {0, 1}, {0, -1}}
+var queenDirs = append(append([]offset{}, bishopDirs...), rookDirs...)
+
+func squareAt(sq Square, o offset) (Square, bool) {
+	f := int8(sq.File()) + o.df
+	r := int8(sq.Rank()) + o.dr
+	if f < 0 || f > 7 || r < 0 || r > 7 {
+		return 0, false
+	}
+	return NewSquare(File(f), Rank(r)), true
+}
+
+func findKing(b *Board, c Color) Square {
+	kp := NewPiece(King, c)
+	for sq := A1; sq <= H8; sq++ {
+		if b.Piece(sq) == kp {
+			return sq
+		}
+	}
+	return NoSquare
+}
+
+func backRankFor(c Color) Rank {
+	if c == Black {
+		return Rank8
+	}
+	return Rank1
+}
+
+func minFile(fs ...File) File {
+	m := fs[0]
+	for _, f := range fs[1:] {
+		if f < m {
+			m = f
+		}
+	}
+	return m
+}
+
+func maxFile(fs ...File) File {
+	m := fs[0]
+	for _, f := range fs[1:] {
+		if f > m {
+			m = f
+		}
+	}
+	return m
+}
+
+func abs8(v int8) int8 {
+	if v < 0 {
+		return -v
+	}
+	return v
+}
+
+// isAttacked reports whether sq is attacked by any piece of color by.
+func isAttacked(b *Board, sq Square, by Color) bool {
+	if sq == NoSquare {
+		return false
+	}
+
+	var pawnOffsets []offset
+	if by == White {
+		pawnOffsets = []offset{{-1, -1}, {1, -1}}
+	} else {
+		pawnOffsets = []offset{{-1, 1}, {1, 1}}
+	}
+	pawnPiece := NewPiece(Pawn, by)
+	for _, o := range pawnOffsets {
+		if s2, ok := squareAt(sq, o); ok && b.Piece(s2) == pawnPiece {
+			return true
+		}
+	}
+
+	knightPiece := NewPiece(Knight, by)
+	for _, o := range knightOffsets {
+		if s2, ok := squareAt(sq, o); ok && b.Piece(s2) == knightPiece {
+			return true
+		}
+	}
+
+	kingPiece := NewPiece(King, by)
+	for _, o := range kingOffsets {
+		if s2, ok := squareAt(sq, o); ok && b.Piece(s2) == kingPiece {
+			return true
+		}
+	}
+
+	bishopPiece := NewPiece(Bishop, by)
+	rookPiece := NewPiece(Rook, by)
+	queenPiece := NewPiece(Queen, by)
+	for _, d := range bishopDirs {
+		cur := sq
+		for {
+			s2, ok := squareAt(cur, d)
+			if !ok {
+				break
+			}
+			p := b.Piece(s2)
+			if p == NoPiece {
+				cur = s2
+				continue
+			}
+			if p == bishopPiece || p == queenPiece {
+				return true
+			}
+			break
+		}
+	}
+	for _, d := range rookDirs {
+		cur := sq
+		for {
+			s2, ok := squareAt(cur, d)
+			if !ok {
+				break
+			}
+			p := b.Piece(s2)
+			if p == NoPiece {
+				cur = s2
+				continue
+			}
+			if p == rookPiece || p == queenPiece {
+				return true
+			}
+			break
+		}
+	}
+	return false
+}
+
+func (pos *Position) pseudoLegalMoves() []*Move {
+	var moves []*Move
+	b := pos.board
+	c := pos.turn
+	for sq := A1; sq <= H8; sq++ {
+		p := b.Piece(sq)
+		if p == NoPiece || p.Color() != c {
+			continue
+		}
+		switch p.Type() {
+		case Pawn:
+			moves = append(moves, pos.pawnMoves(sq)...)
+		case Knight:
+			moves = append(moves, pos.jumpMoves(sq, knightOffsets)...)
+		case Bishop:
+			moves = append(moves, pos.slideMoves(sq, bishopDirs)...)
+		case Rook:
+			moves = append(moves, pos.slideMoves(sq, rookDirs)...)
+		case Queen:
+			moves = append(moves, pos.slideMoves(sq, queenDirs)...)
+		case King:
+			moves = append(moves, pos.jumpMoves(sq, kingOffsets)...)
+			moves = append(moves, pos.castleMoves(sq)...)
+		}
+	}
+	if pos.variant == VariantCrazyhouse {
+		moves = append(moves, pos.dropMoves()...)
+	}
+	return moves
+}
+
+// dropMoves returns a Drop move for every empty square onto which the side
+// to move could place a piece from its pocket. It is only meaningful under
+// VariantCrazyhouse.
+func (pos *Position) dropMoves() []*Move {
+	var moves []*Move
+	pocket := pos.pockets[colorIndex(pos.turn)]
+	for pt, n := range pocket {
+		if n <= 0 {
+			continue
+		}
+		for sq := A1; sq <= H8; sq++ {
+			if pos.board.Piece(sq) != NoPiece {
+				continue
+			}
+			if pt == Pawn && (sq.Rank() == Rank1 || sq.Rank() == Rank8) {
+				continue
+			}
+			moves = append(moves, &Move{s1: NoSquare, s2: sq, piece: NewPiece(pt, pos.turn), tags: Drop})
+		}
+	}
+	return moves
+}
+
+func (pos *Position) jumpMoves(sq Square, offsets []offset) []*Move {
+	var moves []*Move
+	b := pos.board
+	for _, o := range offsets {
+		s2, ok := squareAt(sq, o)
+		if !ok {
+			continue
+		}
+		target := b.Piece(s2)
+		if target != NoPiece && target.Color() == pos.turn {
+			continue
+		}
+		m := &Move{s1: sq, s2: s2, piece: b.Piece(sq)}
+		if target != NoPiece {
+			m.tags |= Capture
+		}
+		moves = append(moves, m)
+	}
+	return moves
+}
+
+func (pos *Position) slideMoves(sq Square, dirs []offset) []*Move {
+	var moves []*Move
+	b := pos.board
+	for _, d := range dirs {
+		cur := sq
+		for {
+			s2, ok := squareAt(cur, d)
+			if !ok {
+				break
+			}
+			target := b.Piece(s2)
+			if target == NoPiece {
+				moves = append(moves, &Move{s1: sq, s2: s2, piece: b.Piece(sq)})
+				cur = s2
+				continue
+			}
+			if target.Color() != pos.turn {
+				moves = append(moves, &Move{s1: sq, s2: s2, tags: Capture, piece: b.Piece(sq)})
+			}
+			break
+		}
+	}
+	return moves
+}
+
+func (pos *Position) pawnMoves(sq Square) []*Move {
+	var moves []*Move
+	b := pos.board
+	c := pos.turn
+	dir := int8(1)
+	startRank := Rank2
+	promoRank := Rank8
+	if c == Black {
+		dir = -1
+		startRank = Rank7
+		promoRank = Rank1
+	}
+	f := sq.File()
+	r := sq.Rank()
+
+	addMove := func(s2 Square, tags MoveTag) {
+		if s2.Rank() == promoRank {
+			for _, pt := range PromoPieceTypes {
+				moves = append(moves, &Move{s1: sq, s2: s2, promo: pt, tags: tags, piece: b.Piece(sq)})
+			}
+			return
+		}
+		moves = append(moves, &Move{s1: sq, s2: s2, tags: tags, piece: b.Piece(sq)})
+	}
+
+	if nr := int8(r) + dir; nr >= 0 && nr <= 7 {
+		s2 := NewSquare(f, Rank(nr))
+		if b.Piece(s2) == NoPiece {
+			addMove(s2, 0)
+			if r == startRank {
+				s3 := NewSquare(f, Rank(int8(r)+2*dir))
+				if b.Piece(s3) == NoPiece {
+					addMove(s3, 0)
+				}
+			}
+		}
+	}
+
+	for _, df := range []int8{-1, 1} {
+		nf := int8(f) + df
+		nr := int8(r) + dir
+		if nf < 0 || nf > 7 || nr < 0 || nr > 7 {
+			continue
+		}
+		s2 := NewSquare(File(nf), Rank(nr))
+		target := b.Piece(s2)
+		if target != NoPiece && target.Color() != c {
+			addMove(s2, Capture)
+		} else if pos.enPassantSquare != NoSquare && s2 == pos.enPassantSquare {
+			addMove(s2, EnPassant)
+		}
+	}
+	return moves
+}
+
+func (pos *Position) castleMoves(kingSq Square) []*Move {
+	var moves []*Move
+	c := pos.turn
+	backRank := backRankFor(c)
+	if kingSq.Rank() != backRank {
+		return moves
+	}
+	if isAttacked(pos.board, kingSq, c.Other()) {
+		return moves
+	}
+	for _, side := range []Side{KingSide, QueenSide} {
+		rookFile, ok := pos.castleRights.RookFile(c, side)
+		if !ok {
+			continue
+		}
+		rookSq := NewSquare(rookFile, backRank)
+		if pos.board.Piece(rookSq) != NewPiece(Rook, c) {
+			continue
+		}
+		kingToFile, rookToFile := FileG, FileF
+		if side == QueenSide {
+			kingToFile, rookToFile = FileC, FileD
+		}
+		kingTo := NewSquare(kingToFile, backRank)
+
+		lo := minFile(kingSq.File(), kingToFile, rookFile, rookToFile)
+		hi := maxFile(kingSq.File(), kingToFile, rookFile, rookToFile)
+		clear := true
+		for fl := lo; fl <= hi; fl++ {
+			s := NewSquare(fl, backRank)
+			if s == kingSq || s == rookSq {
+				continue
+			}
+			if pos.board.Piece(s) != NoPiece {
+				clear = false
+				break
+			}
+		}
+		if !clear {
+			continue
+		}
+
+		loK := minFile(kingSq.File(), kingToFile)
+		hiK := maxFile(kingSq.File(), kingToFile)
+		safe := true
+		for fl := loK; fl <= hiK; fl++ {
+			if isAttacked(pos.board, NewSquare(fl, backRank), c.Other()) {
+				safe = false
+				break
+			}
+		}
+		if !safe {
+			continue
+		}
+
+		tag := KingSideCastle
+		if side == QueenSide {
+			tag = QueenSideCastle
+		}
+		moves = append(moves, &Move{s1: kingSq, s2: kingTo, tags: tag, piece: pos.board.Piece(kingSq)})
+	}
+	return moves
+}
+
+func (pos *Position) hasLegalMove() bool {
+	mover := pos.turn
+	for _, m := range pos.pseudoLegalMoves() {
+		np := pos.Update(m)
+		kingSq := findKing(np.board, mover)
+		if !isAttacked(np.board, kingSq, np.turn) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,447 @@
+package chess
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Encoder turns a Move into its textual representation for some notation.
+type Encoder interface {
+	Encode(pos *Position, m *Move) string
+}
+
+// Decoder turns a notation's textual representation of a move, together
+// with the position it is played from, into a Move.
+type Decoder interface {
+	Decode(pos *Position, s string) (*Move, error)
+}
+
+// Notation is a move notation capable of both encoding and decoding moves,
+// e.g. algebraic notation or UCI's long coordinate notation.
+type Notation interface {
+	Encoder
+	Decoder
+}
+
+var castlePattern = regexp.MustCompile(`^(O-O-O|O-O)([+#])?$`)
+
+func isCapture(m *Move) bool {
+	return m.HasTag(Capture) || m.HasTag(EnPassant)
+}
+
+func checkSuffix(m *Move) string {
+	switch {
+	case m.HasTag(Checkmate):
+		return "#"
+	case m.HasTag(Check):
+		return "+"
+	default:
+		return ""
+	}
+}
+
+func castleMatch(pos *Position, tag MoveTag) (*Move, error) {
+	for _, cand := range pos.ValidMoves() {
+		if cand.HasTag(tag) {
+			return cand, nil
+		}
+	}
+	return nil, fmt.Errorf("chess: castle is not valid in the current position")
+}
+
+// UCINotation encodes and decodes moves in the Universal Chess Interface's
+// long coordinate notation, e.g. "e2e4" or "a7a8q".
+type UCINotation struct {
+	// Chess960 switches castling encoding/decoding to the "king captures
+	// own rook" form UCI engines expect when running Fischer Random
+	// chess, e.g. "e1h1" for kingside castling, rather than the standard
+	// king destination square "e1g1".
+	Chess960 bool
+}
+
+// Encode implements the Encoder interface.
+func (u UCINotation) Encode(pos *Position, m *Move) string {
+	if m.HasTag(Drop) {
+		return m.piece.Type().String() + "@" + m.s2.String()
+	}
+	s2 := m.s2
+	if u.Chess960 && (m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle)) {
+		side := KingSide
+		if m.HasTag(QueenSideCastle) {
+			side = QueenSide
+		}
+		if rookFile, ok := pos.castleRights.RookFile(pos.turn, side); ok {
+			s2 = NewSquare(rookFile, m.s1.Rank())
+		}
+	}
+	s := m.s1.String() + s2.String()
+	if m.promo != NoPieceType {
+		s += strings.ToLower(m.promo.String())
+	}
+	return s
+}
+
+// Decode implements the Decoder interface.
+func (u UCINotation) Decode(pos *Position, s string) (*Move, error) {
+	if at := strings.IndexByte(s, '@'); at >= 0 {
+		return decodeUCIDrop(pos, s, at)
+	}
+	if len(s) != 4 && len(s) != 5 {
+		return nil, fmt.Errorf("chess: invalid UCI notation %q", s)
+	}
+	s1, ok := squareFromString(s[0:2])
+	if !ok {
+		return nil, fmt.Errorf("chess: invalid UCI notation %q: bad origin square", s)
+	}
+	s2, ok := squareFromString(s[2:4])
+	if !ok {
+		return nil, fmt.Errorf("chess: invalid UCI notation %q: bad destination square", s)
+	}
+
+	promo := NoPieceType
+	if len(s) == 5 {
+		pt, ok := charToPieceTypeMap[strings.ToUpper(s[4:5])]
+		if !ok || pt == King || pt == Pawn {
+			return nil, fmt.Errorf("chess: invalid UCI notation %q: bad promotion piece", s)
+		}
+		promo = pt
+	}
+
+	if pos == nil {
+		return nil, fmt.Errorf("chess: cannot decode UCI notation %q without a position", s)
+	}
+
+	if u.Chess960 {
+		mover := pos.board.Piece(s1)
+		target := pos.board.Piece(s2)
+		if mover.Type() == King && mover.Color() == pos.turn && target.Type() == Rook && target.Color() == pos.turn {
+			kingToFile := FileG
+			if s2.File() < s1.File() {
+				kingToFile = FileC
+			}
+			s2 = NewSquare(kingToFile, s1.Rank())
+		}
+	}
+
+	for _, m := range pos.ValidMoves() {
+		if m.s1 == s1 && m.s2 == s2 && m.promo == promo {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("chess: move %q is not valid in the current position", s)
+}
+
+// decodeUCIDrop decodes a Crazyhouse drop of the form "P@e4" or "N@f3",
+// where at is the index of the "@" within s.
+func decodeUCIDrop(pos *Position, s string, at int) (*Move, error) {
+	if at != 1 {
+		return nil, fmt.Errorf("chess: invalid UCI drop notation %q", s)
+	}
+	pt, ok := charToPieceTypeMap[strings.ToUpper(s[0:1])]
+	if !ok || pt == King {
+		return nil, fmt.Errorf("chess: invalid UCI drop notation %q: bad piece", s)
+	}
+	sq, ok := squareFromString(s[at+1:])
+	if !ok {
+		return nil, fmt.Errorf("chess: invalid UCI drop notation %q: bad square", s)
+	}
+	if pos == nil {
+		return nil, fmt.Errorf("chess: cannot decode UCI notation %q without a position", s)
+	}
+	for _, m := range pos.ValidMoves() {
+		if m.HasTag(Drop) && m.s2 == sq && m.piece.Type() == pt {
+			return m, nil
+		}
+	}
+	return nil, fmt.Errorf("chess: drop %q is not valid in the current position", s)
+}
+
+var sanPattern = regexp.MustCompile(`^([KQRBN])?([a-h])?([1-8])?(x)?([a-h][1-8])(=[QRBN])?([+#])?$`)
+var dropPattern = regexp.MustCompile(`^([QRBN])?@([a-h][1-8])([+#])?$`)
+
+// AlgebraicNotation encodes and decodes moves in standard algebraic
+// notation (SAN), e.g. "e4", "Nxf7+", "O-O-O".
+type AlgebraicNotation struct{}
+
+// Encode implements the Encoder interface.
+func (AlgebraicNotation) Encode(pos *Position, m *Move) string {
+	if m.HasTag(Drop) {
+		sb := &strings.Builder{}
+		if m.piece.Type() != Pawn {
+			sb.WriteString(m.piece.Type().String())
+		}
+		sb.WriteString("@")
+		sb.WriteString(m.s2.String())
+		sb.WriteString(checkSuffix(m))
+		return sb.String()
+	}
+	if m.HasTag(KingSideCastle) {
+		return "O-O" + checkSuffix(m)
+	}
+	if m.HasTag(QueenSideCastle) {
+		return "O-O-O" + checkSuffix(m)
+	}
+
+	piece := pos.board.Piece(m.s1)
+	sb := &strings.Builder{}
+	if piece.Type() != Pawn {
+		sb.WriteString(piece.Type().String())
+		sb.WriteString(disambiguation(pos, m, piece))
+	} else if isCapture(m) {
+		sb.WriteString(m.s1.File().String())
+	}
+	if isCapture(m) {
+		sb.WriteString("x")
+	}
+	sb.WriteString(m.s2.String())
+	if m.promo != NoPieceType {
+		sb.WriteString("=" + m.promo.String())
+	}
+	sb.WriteString(checkSuffix(m))
+	return sb.String()
+}
+
+// disambiguation returns the minimal origin-square hint (file, rank, or
+// both) needed to distinguish m from other legal moves of the same piece
+// type to the same destination.
+func disambiguation(pos *Position, m *Move, piece Piece) string {
+	var any, sameFile, sameRank bool
+	for _, cand := range pos.ValidMoves() {
+		if cand.s2 != m.s2 || cand.s1 == m.s1 {
+			continue
+		}
+		candPiece := pos.board.Piece(cand.s1)
+		if candPiece.Type() != piece.Type() || candPiece.Color() != piece.Color() {
+			continue
+		}
+		any = true
+		if cand.s1.File() == m.s1.File() {
+			sameFile = true
+		}
+		if cand.s1.Rank() == m.s1.Rank() {
+			sameRank = true
+		}
+	}
+	switch {
+	case !any:
+		return ""
+	case !sameFile:
+		return m.s1.File().String()
+	case !sameRank:
+		return m.s1.Rank().String()
+	default:
+		return m.s1.String()
+	}
+}
+
+// Decode implements the Decoder interface.
+func (AlgebraicNotation) Decode(pos *Position, s string) (*Move, error) {
+	if pos == nil {
+		return nil, fmt.Errorf("chess: cannot decode algebraic notation %q without a position", s)
+	}
+
+	if dm := dropPattern.FindStringSubmatch(s); dm != nil {
+		return decodeAlgebraicDrop(pos, dm)
+	}
+
+	if cm := castlePattern.FindStringSubmatch(s); cm != nil {
+		tag := KingSideCastle
+		if cm[1] == "O-O-O" {
+			tag = QueenSideCastle
+		}
+		return castleMatch(pos, tag)
+	}
+
+	m := sanPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("chess: invalid algebraic notation %q", s)
+	}
+	pieceStr, fileStr, rankStr, captureStr, destStr, promoStr := m[1], m[2], m[3], m[4], m[5], m[6]
+
+	pieceType := Pawn
+	if pieceStr != "" {
+		pieceType = charToPieceTypeMap[pieceStr]
+	}
+	if pieceType == Pawn && fileStr != "" && captureStr == "" {
+		return nil, fmt.Errorf("chess: invalid algebraic notation %q: pawn moves cannot disambiguate by file without a capture", s)
+	}
+
+	dest, ok := squareFromString(destStr)
+	if !ok {
+		return nil, fmt.Errorf("chess: invalid algebraic notation %q: bad destination square", s)
+	}
+
+	var promo PieceType
+	if promoStr != "" {
+		promo = charToPieceTypeMap[promoStr[1:]]
+	}
+
+	var matches []*Move
+	for _, cand := range pos.ValidMoves() {
+		if cand.HasTag(KingSideCastle) || cand.HasTag(QueenSideCastle) {
+			continue
+		}
+		if cand.s2 != dest || cand.promo != promo {
+			continue
+		}
+		candPiece := pos.board.Piece(cand.s1)
+		if candPiece.Type() != pieceType {
+			continue
+		}
+		if fileStr != "" && cand.s1.File().String() != fileStr {
+			continue
+		}
+		if rankStr != "" && cand.s1.Rank().String() != rankStr {
+			continue
+		}
+		if (captureStr != "") != isCapture(cand) {
+			continue
+		}
+		matches = append(matches, cand)
+	}
+	if len(matches) != 1 {
+		return nil, fmt.Errorf("chess: algebraic notation %q does not uniquely identify a legal move (%d matches)", s, len(matches))
+	}
+	return matches[0], nil
+}
+
+// decodeAlgebraicDrop decodes a Crazyhouse drop matched by dropPattern,
+// e.g. "@e4" (pawn) or "N@f3".
+func decodeAlgebraicDrop(pos *Position, dm []string) (*Move, error) {
+	pieceStr, destStr := dm[1], dm[2]
+	pieceType := Pawn
+	if pieceStr != "" {
+		pieceType = charToPieceTypeMap[pieceStr]
+	}
+	dest, ok := squareFromString(destStr)
+	if !ok {
+		return nil, fmt.Errorf("chess: invalid drop notation %q: bad square", dm[0])
+	}
+	for _, cand := range pos.ValidMoves() {
+		if cand.HasTag(Drop) && cand.s2 == dest && cand.piece.Type() == pieceType {
+			return cand, nil
+		}
+	}
+	return nil, fmt.Errorf("chess: drop %q is not valid in the current position", dm[0])
+}
+
+var longAlgebraicPattern = regexp.MustCompile(`^([KQRBN])?([a-h][1-8])(x)?([a-h][1-8])(=[QRBN])?([+#])?$`)
+
+// LongAlgebraicNotation encodes and decodes moves in long algebraic
+// notation, which spells out both the origin and destination square, e.g.
+// "e2e4" or "Ne5xf7".
+type LongAlgebraicNotation struct{}
+
+// Encode implements the Encoder interface.
+func (LongAlgebraicNotation) Encode(pos *Position, m *Move) string {
+	if m.HasTag(KingSideCastle) {
+		return "O-O" + checkSuffix(m)
+	}
+	if m.HasTag(QueenSideCastle) {
+		return "O-O-O" + checkSuffix(m)
+	}
+
+	piece := pos.board.Piece(m.s1)
+	sb := &strings.Builder{}
+	if piece.Type() != Pawn {
+		sb.WriteString(piece.Type().String())
+	}
+	sb.WriteString(m.s1.String())
+	if isCapture(m) {
+		sb.WriteString("x")
+	}
+	sb.WriteString(m.s2.String())
+	if m.promo != NoPieceType {
+		sb.WriteString("=" + m.promo.String())
+	}
+	sb.WriteString(checkSuffix(m))
+	return sb.String()
+}
+
+// Decode implements the Decoder interface.
+func (LongAlgebraicNotation) Decode(pos *Position, s string) (*Move, error) {
+	if pos == nil {
+		return nil, fmt.Errorf("chess: cannot decode long algebraic notation %q without a position", s)
+	}
+
+	if cm := castlePattern.FindStringSubmatch(s); cm != nil {
+		tag := KingSideCastle
+		if cm[1] == "O-O-O" {
+			tag = QueenSideCastle
+		}
+		return castleMatch(pos, tag)
+	}
+
+	m := longAlgebraicPattern.FindStringSubmatch(s)
+	if m == nil {
+		return nil, fmt.Errorf("chess: invalid long algebraic notation %q", s)
+	}
+	pieceStr, s1Str, captureStr, s2Str, promoStr := m[1], m[2], m[3], m[4], m[5]
+
+	pieceType := Pawn
+	if pieceStr != "" {
+		pieceType = charToPieceTypeMap[pieceStr]
+	}
+	s1, ok := squareFromString(s1Str)
+	if !ok {
+		return nil, fmt.Errorf("chess: invalid long algebraic notation %q: bad origin square", s)
+	}
+	s2, ok := squareFromString(s2Str)
+	if !ok {
+		return nil, fmt.Errorf("chess: invalid long algebraic notation %q: bad destination square", s)
+	}
+	var promo PieceType
+	if promoStr != "" {
+		promo = charToPieceTypeMap[promoStr[1:]]
+	}
+
+	for _, cand := range pos.ValidMoves() {
+		if cand.s1 != s1 || cand.s2 != s2 || cand.promo != promo {
+			continue
+		}
+		candPiece := pos.board.Piece(cand.s1)
+		if candPiece.Type() != pieceType {
+			continue
+		}
+		if (captureStr != "") != isCapture(cand) {
+			continue
+		}
+		return cand, nil
+	}
+	return nil, fmt.Errorf("chess: long algebraic notation %q is not valid in the current position", s)
+}
+
+// ShredderFENNotation encodes castling rights using Shredder-FEN's
+// file-letter convention (e.g. "HAha") instead of "KQkq", so that Chess960
+// positions whose rooks did not start on the a- and h-files round-trip
+// through FEN correctly. It has no Decode method; the FEN decoder reads
+// file-letter castling fields itself.
+type ShredderFENNotation struct{}
+
+// Encode returns the Shredder-FEN castling field for pos, e.g. "HAha".
+func (ShredderFENNotation) Encode(pos *Position) string {
+	sb := &strings.Builder{}
+	for _, c := range []Color{White, Black} {
+		var fs []File
+		for _, side := range []Side{KingSide, QueenSide} {
+			if f, ok := pos.castleRights.RookFile(c, side); ok {
+				fs = append(fs, f)
+			}
+		}
+		if len(fs) == 2 && fs[0] < fs[1] {
+			fs[0], fs[1] = fs[1], fs[0]
+		}
+		for _, f := range fs {
+			letter := f.String()
+			if c == White {
+				letter = strings.ToUpper(letter)
+			}
+			sb.WriteString(letter)
+		}
+	}
+	if sb.Len() == 0 {
+		return "-"
+	}
+	return sb.String()
+}
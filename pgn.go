@@ -0,0 +1,55 @@
+package chess
+
+import (
+	"io"
+	"regexp"
+	"strings"
+)
+
+var (
+	pgnTagPairPattern = regexp.MustCompile(`(?m)^\[.*\]\s*$`)
+	pgnMoveNumPattern = regexp.MustCompile(`\d+\.(\.\.)?`)
+	pgnCommentPattern = regexp.MustCompile(`\{[^}]*\}`)
+)
+
+var pgnResultTokens = map[string]bool{
+	"*":       true,
+	"1-0":     true,
+	"0-1":     true,
+	"1/2-1/2": true,
+}
+
+// PGN parses a game in Portable Game Notation from r and returns a Game
+// option that replays its movetext, in order, from the standard starting
+// position. Tag pairs, move numbers, and comments are ignored; the result
+// token (if any) ends the game without error.
+func PGN(r io.Reader) (func(*Game), error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+
+	movetext := pgnTagPairPattern.ReplaceAllString(string(data), "")
+	movetext = pgnCommentPattern.ReplaceAllString(movetext, "")
+	movetext = pgnMoveNumPattern.ReplaceAllString(movetext, "")
+
+	var sanMoves []string
+	for _, f := range strings.Fields(movetext) {
+		if pgnResultTokens[f] {
+			break
+		}
+		sanMoves = append(sanMoves, f)
+	}
+
+	return func(g *Game) {
+		notation := AlgebraicNotation{}
+		for _, s := range sanMoves {
+			m, err := notation.Decode(g.Position(), s)
+			if err != nil {
+				return
+			}
+			g.moves = append(g.moves, m)
+			g.positions = append(g.positions, m.Position())
+		}
+	}, nil
+}
@@ -0,0 +1,132 @@
+package chess
+
+// PieceType is the type of a piece, independent of color.
+type PieceType int8
+
+const (
+	NoPieceType PieceType = iota
+	King
+	Queen
+	Rook
+	Bishop
+	Knight
+	Pawn
+)
+
+// PromoPieceTypes are the piece types a pawn may promote to, in the order
+// notation decoders should prefer when a promotion character is ambiguous.
+var PromoPieceTypes = []PieceType{Queen, Rook, Bishop, Knight}
+
+var pieceTypeToCharMap = map[PieceType]string{
+	King:   "K",
+	Queen:  "Q",
+	Rook:   "R",
+	Bishop: "B",
+	Knight: "N",
+	Pawn:   "P",
+}
+
+var charToPieceTypeMap = map[string]PieceType{
+	"K": King,
+	"Q": Queen,
+	"R": Rook,
+	"B": Bishop,
+	"N": Knight,
+	"P": Pawn,
+}
+
+func (p PieceType) String() string {
+	return pieceTypeToCharMap[p]
+}
+
+// Piece is a piece type bound to a color, e.g. a white knight.
+type Piece int8
+
+const (
+	NoPiece Piece = iota
+	WhiteKing
+	WhiteQueen
+	WhiteRook
+	WhiteBishop
+	WhiteKnight
+	WhitePawn
+	BlackKing
+	BlackQueen
+	BlackRook
+	BlackBishop
+	BlackKnight
+	BlackPawn
+)
+
+var pieceToFENCharMap = map[Piece]string{
+	WhiteKing:   "K",
+	WhiteQueen:  "Q",
+	WhiteRook:   "R",
+	WhiteBishop: "B",
+	WhiteKnight: "N",
+	WhitePawn:   "P",
+	BlackKing:   "k",
+	BlackQueen:  "q",
+	BlackRook:   "r",
+	BlackBishop: "b",
+	BlackKnight: "n",
+	BlackPawn:   "p",
+}
+
+var fenCharToPieceMap = func() map[string]Piece {
+	m := map[string]Piece{}
+	for p, s := range pieceToFENCharMap {
+		m[s] = p
+	}
+	return m
+}()
+
+// NewPiece returns the piece of the given type and color.
+func NewPiece(t PieceType, c Color) Piece {
+	if t == NoPieceType || c == NoColor {
+		return NoPiece
+	}
+	if c == White {
+		return Piece(t)
+	}
+	return Piece(int8(King) + int8(t) + 5)
+}
+
+// Color returns the piece's color.
+func (p Piece) Color() Color {
+	switch {
+	case p == NoPiece:
+		return NoColor
+	case p <= WhitePawn:
+		return White
+	default:
+		return Black
+	}
+}
+
+// Type returns the piece's type, independent of color.
+func (p Piece) Type() PieceType {
+	switch p {
+	case WhiteKing, BlackKing:
+		return King
+	case WhiteQueen, BlackQueen:
+		return Queen
+	case WhiteRook, BlackRook:
+		return Rook
+	case WhiteBishop, BlackBishop:
+		return Bishop
+	case WhiteKnight, BlackKnight:
+		return Knight
+	case WhitePawn, BlackPawn:
+		return Pawn
+	default:
+		return NoPieceType
+	}
+}
+
+func (p Piece) String() string {
+	if p == NoPiece {
+		return ""
+	}
+	return pieceToFENCharMap[p]
+}
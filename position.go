@@ -0,0 +1,267 @@
+package chess
+
+import "fmt"
+
+// Position represents the full state of a chess game at a single point in
+// time: the board, whose turn it is, castling rights, the en passant
+// target square, and the move clocks needed to reproduce its FEN.
+type Position struct {
+	board           *Board
+	turn            Color
+	castleRights    CastleRights
+	enPassantSquare Square
+	halfMoveClock   int
+	moveCount       int
+	variant         Variant
+	// pockets holds, per color (indexed by colorIndex), the count of each
+	// piece type available to drop. Only meaningful under
+	// VariantCrazyhouse.
+	pockets [2]map[PieceType]int
+	// checksRemaining holds, per color (indexed by colorIndex), the
+	// number of checks that color must still deliver to win. Only
+	// meaningful under VariantThreeCheck.
+	checksRemaining [2]int
+}
+
+// Board returns the position's board.
+func (pos *Position) Board() *Board {
+	return pos.board
+}
+
+// Turn returns the color to move.
+func (pos *Position) Turn() Color {
+	return pos.turn
+}
+
+// CastleRights returns the position's castling rights.
+func (pos *Position) CastleRights() CastleRights {
+	return pos.castleRights
+}
+
+// EnPassantSquare returns the position's en passant target square, or
+// NoSquare if none is set.
+func (pos *Position) EnPassantSquare() Square {
+	return pos.enPassantSquare
+}
+
+// Variant returns the rule set pos is being played under.
+func (pos *Position) Variant() Variant {
+	return pos.variant
+}
+
+// Pocket returns the count of each piece type color has available to drop.
+// It is only meaningful under VariantCrazyhouse; other variants return an
+// empty map.
+func (pos *Position) Pocket(c Color) map[PieceType]int {
+	cp := map[PieceType]int{}
+	for pt, n := range pos.pockets[colorIndex(c)] {
+		cp[pt] = n
+	}
+	return cp
+}
+
+// ChecksRemaining returns the number of checks color must still deliver to
+// win. It is only meaningful under VariantThreeCheck.
+func (pos *Position) ChecksRemaining(c Color) int {
+	return pos.checksRemaining[colorIndex(c)]
+}
+
+// InCheck reports whether the side to move is in check.
+func (pos *Position) InCheck() bool {
+	return isAttacked(pos.board, findKing(pos.board, pos.turn), pos.turn.Other())
+}
+
+// ValidMoves returns every legal move available to the side to move, each
+// tagged with Capture/EnPassant/castle/Check/Checkmate as appropriate and
+// carrying the resulting Position.
+func (pos *Position) ValidMoves() []*Move {
+	mover := pos.turn
+	var legal []*Move
+	for _, m := range pos.pseudoLegalMoves() {
+		np := pos.Update(m)
+		if isAttacked(np.board, findKing(np.board, mover), np.turn) {
+			continue
+		}
+		oppKingSq := findKing(np.board, np.turn)
+		if isAttacked(np.board, oppKingSq, mover) {
+			m.AddTag(Check)
+			if np.variant == VariantThreeCheck {
+				np.checksRemaining[colorIndex(mover)]--
+			}
+			if !np.hasLegalMove() {
+				m.AddTag(Checkmate)
+			}
+		}
+		m.position = np
+		legal = append(legal, m)
+	}
+	return legal
+}
+
+// Update applies m to pos and returns the resulting Position. It does not
+// validate that m is legal; callers that need legality checking should
+// source moves from ValidMoves or a Notation decoder.
+func (pos *Position) Update(m *Move) *Position {
+	np := &Position{
+		board:           pos.board.copy(),
+		turn:            pos.turn.Other(),
+		castleRights:    pos.castleRights,
+		enPassantSquare: NoSquare,
+		halfMoveClock:   pos.halfMoveClock + 1,
+		moveCount:       pos.moveCount,
+		variant:         pos.variant,
+		pockets:         copyPockets(pos.pockets),
+		checksRemaining: pos.checksRemaining,
+	}
+	if pos.turn == Black {
+		np.moveCount = pos.moveCount + 1
+	}
+
+	if m.HasTag(Drop) {
+		np.board.setPiece(m.s2, m.piece)
+		np.pockets[colorIndex(pos.turn)][m.piece.Type()]--
+		if m.piece.Type() == Pawn {
+			np.halfMoveClock = 0
+		}
+		return np
+	}
+
+	moving := pos.board.Piece(m.s1)
+	captured := pos.board.Piece(m.s2)
+	capturedSq := m.s2
+
+	if m.HasTag(EnPassant) {
+		capSq := NewSquare(m.s2.File(), m.s1.Rank())
+		captured = pos.board.Piece(capSq)
+		capturedSq = capSq
+		np.board.setPiece(capSq, NoPiece)
+	}
+
+	if pos.variant == VariantCrazyhouse && captured != NoPiece {
+		pocketType := captured.Type()
+		if pos.board.isPromoted(capturedSq) {
+			pocketType = Pawn
+		}
+		np.pockets[colorIndex(pos.turn)][pocketType]++
+	}
+
+	wasPromoted := pos.board.isPromoted(m.s1)
+	np.board.setPromoted(m.s1, false)
+	np.board.setPiece(m.s1, NoPiece)
+	if m.promo != NoPieceType {
+		np.board.setPiece(m.s2, NewPiece(m.promo, moving.Color()))
+		np.board.setPromoted(m.s2, true)
+	} else {
+		np.board.setPiece(m.s2, moving)
+		np.board.setPromoted(m.s2, wasPromoted)
+	}
+
+	if m.HasTag(KingSideCastle) || m.HasTag(QueenSideCastle) {
+		side := KingSide
+		if m.HasTag(QueenSideCastle) {
+			side = QueenSide
+		}
+		rookFile, _ := pos.castleRights.RookFile(pos.turn, side)
+		backRank := m.s1.Rank()
+		rookToFile := FileF
+		if side == QueenSide {
+			rookToFile = FileD
+		}
+		if rookSq := NewSquare(rookFile, backRank); rookSq != m.s2 {
+			np.board.setPiece(rookSq, NoPiece)
+		}
+		np.board.setPiece(NewSquare(rookToFile, backRank), NewPiece(Rook, pos.turn))
+	}
+
+	if moving.Type() == Pawn || captured != NoPiece {
+		np.halfMoveClock = 0
+	}
+
+	if moving.Type() == Pawn && abs8(int8(m.s2.Rank())-int8(m.s1.Rank())) == 2 {
+		midRank := Rank((int8(m.s1.Rank()) + int8(m.s2.Rank())) / 2)
+		np.enPassantSquare = NewSquare(m.s1.File(), midRank)
+	}
+
+	if moving.Type() == King {
+		np.castleRights.Remove(pos.turn, KingSide)
+		np.castleRights.Remove(pos.turn, QueenSide)
+	}
+	if moving.Type() == Rook && m.s1.Rank() == backRankFor(pos.turn) {
+		for _, side := range []Side{KingSide, QueenSide} {
+			if rf, ok := pos.castleRights.RookFile(pos.turn, side); ok && rf == m.s1.File() {
+				np.castleRights.Remove(pos.turn, side)
+			}
+		}
+	}
+	if captured != NoPiece && captured.Type() == Rook && m.s2.Rank() == backRankFor(pos.turn.Other()) {
+		opp := pos.turn.Other()
+		for _, side := range []Side{KingSide, QueenSide} {
+			if rf, ok := pos.castleRights.RookFile(opp, side); ok && rf == m.s2.File() {
+				np.castleRights.Remove(opp, side)
+			}
+		}
+	}
+
+	return np
+}
+
+// String returns the position encoded as a FEN string. Under
+// VariantCrazyhouse the board field carries a "[...]" pocket suffix, and
+// under VariantThreeCheck a trailing "w+b" remaining-checks field is
+// appended; standard-chess positions are unaffected.
+func (pos *Position) String() string {
+	ep := "-"
+	if pos.enPassantSquare != NoSquare {
+		ep = pos.enPassantSquare.String()
+	}
+	board := pos.board.String()
+	if pos.variant == VariantCrazyhouse {
+		board += "[" + pocketsToFENString(pos.pockets) + "]"
+	}
+	s := fmt.Sprintf("%s %s %s %s %d %d",
+		board, pos.turn.String(), pos.castleRights.String(), ep, pos.halfMoveClock, pos.moveCount)
+	if pos.variant == VariantThreeCheck {
+		s += fmt.Sprintf(" %d+%d", pos.checksRemaining[colorIndex(White)], pos.checksRemaining[colorIndex(Black)])
+	}
+	return s
+}
+
+// copyPockets returns a deep copy of p, so that mutating the result never
+// affects p.
+func copyPockets(p [2]map[PieceType]int) [2]map[PieceType]int {
+	var cp [2]map[PieceType]int
+	for i, m := range p {
+		if m == nil {
+			continue
+		}
+		nm := make(map[PieceType]int, len(m))
+		for pt, n := range m {
+			nm[pt] = n
+		}
+		cp[i] = nm
+	}
+	return cp
+}
+
+// ShredderFENString returns pos encoded as FEN using Shredder-FEN's
+// file-letter castling notation (e.g. "HAha") instead of "KQkq", which is
+// needed to round-trip Chess960 positions whose rooks do not start on the
+// a- and h-files. As with String, under VariantCrazyhouse the board field
+// carries a "[...]" pocket suffix, and under VariantThreeCheck a trailing
+// "w+b" remaining-checks field is appended.
+func (pos *Position) ShredderFENString() string {
+	ep := "-"
+	if pos.enPassantSquare != NoSquare {
+		ep = pos.enPassantSquare.String()
+	}
+	board := pos.board.String()
+	if pos.variant == VariantCrazyhouse {
+		board += "[" + pocketsToFENString(pos.pockets) + "]"
+	}
+	s := fmt.Sprintf("%s %s %s %s %d %d",
+		board, pos.turn.String(), ShredderFENNotation{}.Encode(pos), ep, pos.halfMoveClock, pos.moveCount)
+	if pos.variant == VariantThreeCheck {
+		s += fmt.Sprintf(" %d+%d", pos.checksRemaining[colorIndex(White)], pos.checksRemaining[colorIndex(Black)])
+	}
+	return s
+}
@@ -0,0 +1,179 @@
+package chess
+
+// File represents one of the eight files on a chess board.
+type File int8
+
+const (
+	FileA File = iota
+	FileB
+	FileC
+	FileD
+	FileE
+	FileF
+	FileG
+	FileH
+)
+
+var fileToCharMap = map[File]string{
+	FileA: "a",
+	FileB: "b",
+	FileC: "c",
+	FileD: "d",
+	FileE: "e",
+	FileF: "f",
+	FileG: "g",
+	FileH: "h",
+}
+
+var charToFileMap = map[string]File{
+	"a": FileA,
+	"b": FileB,
+	"c": FileC,
+	"d": FileD,
+	"e": FileE,
+	"f": FileF,
+	"g": FileG,
+	"h": FileH,
+}
+
+func (f File) String() string {
+	return fileToCharMap[f]
+}
+
+// Rank represents one of the eight ranks on a chess board.
+type Rank int8
+
+const (
+	Rank1 Rank = iota
+	Rank2
+	Rank3
+	Rank4
+	Rank5
+	Rank6
+	Rank7
+	Rank8
+)
+
+var rankToCharMap = map[Rank]string{
+	Rank1: "1",
+	Rank2: "2",
+	Rank3: "3",
+	Rank4: "4",
+	Rank5: "5",
+	Rank6: "6",
+	Rank7: "7",
+	Rank8: "8",
+}
+
+func (r Rank) String() string {
+	return rankToCharMap[r]
+}
+
+// Square represents one of the sixty-four squares on a chess board.
+type Square int8
+
+const (
+	A1 Square = iota
+	B1
+	C1
+	D1
+	E1
+	F1
+	G1
+	H1
+	A2
+	B2
+	C2
+	D2
+	E2
+	F2
+	G2
+	H2
+	A3
+	B3
+	C3
+	D3
+	E3
+	F3
+	G3
+	H3
+	A4
+	B4
+	C4
+	D4
+	E4
+	F4
+	G4
+	H4
+	A5
+	B5
+	C5
+	D5
+	E5
+	F5
+	G5
+	H5
+	A6
+	B6
+	C6
+	D6
+	E6
+	F6
+	G6
+	H6
+	A7
+	B7
+	C7
+	D7
+	E7
+	F7
+	G7
+	H7
+	A8
+	B8
+	C8
+	D8
+	E8
+	F8
+	G8
+	H8
+	NoSquare
+)
+
+// NewSquare returns the square at the given file and rank.
+func NewSquare(f File, r Rank) Square {
+	return Square(int8(r)*8 + int8(f))
+}
+
+// File returns the file the square sits on.
+func (sq Square) File() File {
+	return File(int8(sq) % 8)
+}
+
+// Rank returns the rank the square sits on.
+func (sq Square) Rank() Rank {
+	return Rank(int8(sq) / 8)
+}
+
+func (sq Square) String() string {
+	if sq == NoSquare {
+		return "-"
+	}
+	return sq.File().String() + sq.Rank().String()
+}
+
+var squareToStringMap = func() map[string]Square {
+	m := map[string]Square{}
+	for f := FileA; f <= FileH; f++ {
+		for r := Rank1; r <= Rank8; r++ {
+			sq := NewSquare(f, r)
+			m[sq.String()] = sq
+		}
+	}
+	return m
+}()
+
+func squareFromString(s string) (Square, bool) {
+	sq, ok := squareToStringMap[s]
+	return sq, ok
+}
@@ -0,0 +1,29 @@
+package chess
+
+// Variant identifies which rule set a Position is being played under. The
+// zero value, VariantStandard, is ordinary chess; the notation encoders and
+// FEN codec only deviate from standard behavior when a Position carries one
+// of the other variants, so existing standard-chess trees stay unaffected.
+type Variant uint8
+
+const (
+	// VariantStandard is ordinary chess.
+	VariantStandard Variant = iota
+	// VariantCrazyhouse is Crazyhouse: captured pieces join the capturing
+	// side's pocket and may later be dropped back onto any empty square
+	// instead of moved.
+	VariantCrazyhouse
+	// VariantThreeCheck is Three-Check: a player also wins by delivering
+	// check three times over the course of the game.
+	VariantThreeCheck
+)
+
+var variantToStringMap = map[Variant]string{
+	VariantStandard:   "Standard",
+	VariantCrazyhouse: "Crazyhouse",
+	VariantThreeCheck: "Three-check",
+}
+
+func (v Variant) String() string {
+	return variantToStringMap[v]
+}
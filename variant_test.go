@@ -0,0 +1,174 @@
+package chess
+
+import "testing"
+
+func TestCrazyhouseFENPocketRoundTrip(t *testing.T) {
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[QRrbnp] w KQkq - 0 1")
+	if pos.Variant() != VariantCrazyhouse {
+		t.Fatalf("expected VariantCrazyhouse, got %s", pos.Variant())
+	}
+	if got := pos.Pocket(White)[Queen]; got != 1 {
+		t.Fatalf("expected White to have 1 queen in pocket, got %d", got)
+	}
+	if got := pos.Pocket(White)[Rook]; got != 1 {
+		t.Fatalf("expected White to have 1 rook in pocket, got %d", got)
+	}
+	if got := pos.Pocket(Black)[Rook]; got != 1 {
+		t.Fatalf("expected Black to have 1 rook in pocket, got %d", got)
+	}
+	if got := pos.Pocket(Black)[Bishop]; got != 1 {
+		t.Fatalf("expected Black to have 1 bishop in pocket, got %d", got)
+	}
+	if got := pos.Pocket(Black)[Knight]; got != 1 {
+		t.Fatalf("expected Black to have 1 knight in pocket, got %d", got)
+	}
+	if got := pos.Pocket(Black)[Pawn]; got != 1 {
+		t.Fatalf("expected Black to have 1 pawn in pocket, got %d", got)
+	}
+	if pos.String() != "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[RQpnbr] w KQkq - 0 1" {
+		t.Fatalf("did not round-trip, got %q", pos)
+	}
+}
+
+func TestCrazyhouseFENSlashPocketSuffix(t *testing.T) {
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR/QRrbnp w KQkq - 0 1")
+	if pos.Pocket(White)[Queen] != 1 || pos.Pocket(Black)[Pawn] != 1 {
+		t.Fatalf("expected slash-separated pocket suffix to parse, got white=%v black=%v",
+			pos.Pocket(White), pos.Pocket(Black))
+	}
+}
+
+func TestCrazyhouseUCIDropEncodeDecode(t *testing.T) {
+	pos := unsafeFEN("8/8/8/8/8/8/8/4K2k[N] w - - 0 1")
+	notation := UCINotation{}
+
+	move, err := notation.Decode(pos, "N@f3")
+	if err != nil {
+		t.Fatalf("unexpected error decoding drop: %v", err)
+	}
+	if !move.HasTag(Drop) || move.s2 != F3 || move.piece != WhiteKnight {
+		t.Fatalf("expected a white knight drop onto f3, got %+v", move)
+	}
+	if got := notation.Encode(pos, move); got != "N@f3" {
+		t.Fatalf("expected N@f3, got %s", got)
+	}
+
+	next := pos.Update(move)
+	if next.Board().Piece(F3) != WhiteKnight {
+		t.Fatalf("expected a white knight on f3 after the drop")
+	}
+	if got := next.Pocket(White)[Knight]; got != 0 {
+		t.Fatalf("expected White's pocket knight to be consumed, got %d remaining", got)
+	}
+}
+
+func TestCrazyhouseAlgebraicDropEncodeDecode(t *testing.T) {
+	pos := unsafeFEN("8/8/8/8/8/8/8/4K2k[Pn] w - - 0 1")
+	notation := AlgebraicNotation{}
+
+	pawnDrop, err := notation.Decode(pos, "@e4")
+	if err != nil {
+		t.Fatalf("unexpected error decoding pawn drop: %v", err)
+	}
+	if pawnDrop.piece.Type() != Pawn || pawnDrop.s2 != E4 {
+		t.Fatalf("expected a pawn drop onto e4, got %+v", pawnDrop)
+	}
+	if got := notation.Encode(pos, pawnDrop); got != "@e4" {
+		t.Fatalf("expected @e4, got %s", got)
+	}
+}
+
+func TestCrazyhousePromotedPieceEntersPocketAsPawn(t *testing.T) {
+	white := unsafeFEN("7k/1P6/8/8/8/8/8/K7 w - - 0 1")
+	promoMove := &Move{s1: B7, s2: B8, promo: Queen, piece: WhitePawn}
+	promoted := white.Update(promoMove)
+	if !promoted.Board().isPromoted(B8) {
+		t.Fatalf("expected the promoted queen on b8 to be tracked as promoted")
+	}
+
+	crazyhouse := &Position{
+		board:           promoted.Board(),
+		turn:            Black,
+		castleRights:    CastleRights{},
+		enPassantSquare: NoSquare,
+		halfMoveClock:   0,
+		moveCount:       1,
+		variant:         VariantCrazyhouse,
+		pockets:         [2]map[PieceType]int{{}, {}},
+	}
+	capture := &Move{s1: H8, s2: B8, piece: BlackKing}
+	next := crazyhouse.Update(capture)
+	if got := next.Pocket(Black)[Pawn]; got != 1 {
+		t.Fatalf("expected the captured promoted queen to enter Black's pocket as a pawn, got pocket %v", next.Pocket(Black))
+	}
+	if got := next.Pocket(Black)[Queen]; got != 0 {
+		t.Fatalf("expected no queen in Black's pocket, got %d", got)
+	}
+}
+
+func TestThreeCheckFENRoundTrip(t *testing.T) {
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1 3+3")
+	if pos.Variant() != VariantThreeCheck {
+		t.Fatalf("expected VariantThreeCheck, got %s", pos.Variant())
+	}
+	if pos.ChecksRemaining(White) != 3 || pos.ChecksRemaining(Black) != 3 {
+		t.Fatalf("expected 3+3 remaining checks, got white=%d black=%d",
+			pos.ChecksRemaining(White), pos.ChecksRemaining(Black))
+	}
+	if pos.String() != "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1 3+3" {
+		t.Fatalf("did not round-trip, got %q", pos)
+	}
+}
+
+func TestCrazyhouseShredderFENPocketSuffix(t *testing.T) {
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[RQ] w KQkq - 0 1")
+	if got, want := pos.ShredderFENString(), "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR[RQ] w HAha - 0 1"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestThreeCheckZeroChecksRemainingFormat(t *testing.T) {
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1 +0+0")
+	if pos.ChecksRemaining(White) != 0 || pos.ChecksRemaining(Black) != 0 {
+		t.Fatalf("expected 0+0 remaining checks, got white=%d black=%d",
+			pos.ChecksRemaining(White), pos.ChecksRemaining(Black))
+	}
+}
+
+func TestThreeCheckCounterDecrementsOnCheck(t *testing.T) {
+	pos := unsafeFEN("4k3/8/8/8/8/8/8/R3K3 w - - 0 1 3+3")
+	var checkMove *Move
+	for _, m := range pos.ValidMoves() {
+		if m.HasTag(Check) {
+			checkMove = m
+			break
+		}
+	}
+	if checkMove == nil {
+		t.Fatal("expected at least one checking move from the rook")
+	}
+	next := checkMove.Position()
+	if next.ChecksRemaining(White) != 2 {
+		t.Fatalf("expected White's remaining checks to drop to 2, got %d", next.ChecksRemaining(White))
+	}
+	if next.ChecksRemaining(Black) != 3 {
+		t.Fatalf("expected Black's remaining checks to stay at 3, got %d", next.ChecksRemaining(Black))
+	}
+}
+
+func TestThreeCheckShredderFENChecksField(t *testing.T) {
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1 3+3")
+	if got, want := pos.ShredderFENString(), "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w HAha - 0 1 3+3"; got != want {
+		t.Fatalf("expected %q, got %q", want, got)
+	}
+}
+
+func TestStandardVariantFENUnaffected(t *testing.T) {
+	pos := unsafeFEN("rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1")
+	if pos.Variant() != VariantStandard {
+		t.Fatalf("expected VariantStandard, got %s", pos.Variant())
+	}
+	if pos.String() != "rnbqkbnr/pppppppp/8/8/8/8/PPPPPPPP/RNBQKBNR w KQkq - 0 1" {
+		t.Fatalf("standard FEN should round-trip byte-identically, got %q", pos)
+	}
+}